@@ -0,0 +1,61 @@
+package tomlbuilder_test
+
+import (
+	"fmt"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleTomlBuilder_Comment() {
+	builder := tomlbuilder.New()
+	builder.Comment("Server configuration").AddString("host", "localhost")
+	builder.AddInt("port", 8080)
+	fmt.Println(builder.String())
+	// Output:
+	// # Server configuration
+	// host = "localhost"
+	// port = 8080
+}
+
+func ExampleAddTableC() {
+	builder := tomlbuilder.New()
+	builder.AddTableC("server", "Server configuration", func(b *tomlbuilder.TomlBuilder) {
+		b.AddString("host", "localhost")
+	})
+	fmt.Println(builder.String())
+	// Output:
+	// # Server configuration
+	// [server]
+	// host = "localhost"
+}
+
+func ExampleTomlBuilder_SetKeyValueAlignment() {
+	builder := tomlbuilder.New()
+	builder.SetKeyValueAlignment(true)
+	builder.AddString("name", "alpha")
+	builder.AddInt("port", 8080)
+	builder.AddBool("tls", true)
+	fmt.Println(builder.String())
+	// Output:
+	// name = "alpha"
+	// port = 8080
+	// tls  = true
+}
+
+func ExampleTomlBuilder_TableSpacing() {
+	builder := tomlbuilder.New()
+	builder.TableSpacing = 1
+	builder.AddTable("a", func(b *tomlbuilder.TomlBuilder) {
+		b.AddInt("x", 1)
+	})
+	builder.AddTable("b", func(b *tomlbuilder.TomlBuilder) {
+		b.AddInt("x", 2)
+	})
+	fmt.Println(builder.String())
+	// Output:
+	// [a]
+	// x = 1
+	//
+	// [b]
+	// x = 2
+}
@@ -0,0 +1,127 @@
+package tomlbuilder
+
+import (
+	"fmt"
+	"time"
+)
+
+// LocalDate represents a TOML Local Date, a calendar date with no time or
+// offset component (e.g. 1979-05-27).
+type LocalDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewLocalDate creates a LocalDate.
+func NewLocalDate(year int, month time.Month, day int) LocalDate {
+	return LocalDate{Year: year, Month: month, Day: day}
+}
+
+// String formats the LocalDate per the TOML 1.0 spec, e.g. "1979-05-27".
+func (d LocalDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// LocalTime represents a TOML Local Time, a time of day with no offset
+// component (e.g. 07:32:00).
+type LocalTime struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// NewLocalTime creates a LocalTime.
+func NewLocalTime(hour, minute, second, nanosecond int) LocalTime {
+	return LocalTime{Hour: hour, Minute: minute, Second: second, Nanosecond: nanosecond}
+}
+
+// String formats the LocalTime per the TOML 1.0 spec, e.g. "07:32:00" or
+// "00:32:00.999999" when there is a fractional component.
+func (t LocalTime) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+	frac := fmt.Sprintf("%09d", t.Nanosecond)
+	for len(frac) > 0 && frac[len(frac)-1] == '0' {
+		frac = frac[:len(frac)-1]
+	}
+	return s + "." + frac
+}
+
+// LocalDateTime represents a TOML Local Date-time, a date and time with no
+// offset component (e.g. 1979-05-27T07:32:00).
+type LocalDateTime struct {
+	LocalDate
+	LocalTime
+}
+
+// NewLocalDateTime creates a LocalDateTime.
+func NewLocalDateTime(date LocalDate, time LocalTime) LocalDateTime {
+	return LocalDateTime{LocalDate: date, LocalTime: time}
+}
+
+// String formats the LocalDateTime per the TOML 1.0 spec, e.g.
+// "1979-05-27T07:32:00".
+func (dt LocalDateTime) String() string {
+	return dt.LocalDate.String() + "T" + dt.LocalTime.String()
+}
+
+// AddDateTime adds a TOML Offset Date-time key-value pair to the builder,
+// formatted per RFC 3339, e.g. "1979-05-27T07:32:00Z".
+func (w *TomlBuilder) AddDateTime(key string, value time.Time) {
+	w.addKV(key, value.Format(time.RFC3339Nano))
+}
+
+// AddLocalDateTime adds a TOML Local Date-time key-value pair to the builder.
+func (w *TomlBuilder) AddLocalDateTime(key string, value LocalDateTime) {
+	w.addKV(key, value.String())
+}
+
+// AddLocalDate adds a TOML Local Date key-value pair to the builder.
+func (w *TomlBuilder) AddLocalDate(key string, value LocalDate) {
+	w.addKV(key, value.String())
+}
+
+// AddLocalTime adds a TOML Local Time key-value pair to the builder.
+func (w *TomlBuilder) AddLocalTime(key string, value LocalTime) {
+	w.addKV(key, value.String())
+}
+
+// AddDateTimeArray adds an array of TOML Offset Date-times to the builder.
+func (w *TomlBuilder) AddDateTimeArray(key string, array ...time.Time) {
+	vals := make([]string, len(array))
+	for i, val := range array {
+		vals[i] = val.Format(time.RFC3339Nano)
+	}
+	w.addArray(key, vals)
+}
+
+// AddLocalDateTimeArray adds an array of TOML Local Date-times to the builder.
+func (w *TomlBuilder) AddLocalDateTimeArray(key string, array ...LocalDateTime) {
+	vals := make([]string, len(array))
+	for i, val := range array {
+		vals[i] = val.String()
+	}
+	w.addArray(key, vals)
+}
+
+// AddLocalDateArray adds an array of TOML Local Dates to the builder.
+func (w *TomlBuilder) AddLocalDateArray(key string, array ...LocalDate) {
+	vals := make([]string, len(array))
+	for i, val := range array {
+		vals[i] = val.String()
+	}
+	w.addArray(key, vals)
+}
+
+// AddLocalTimeArray adds an array of TOML Local Times to the builder.
+func (w *TomlBuilder) AddLocalTimeArray(key string, array ...LocalTime) {
+	vals := make([]string, len(array))
+	for i, val := range array {
+		vals[i] = val.String()
+	}
+	w.addArray(key, vals)
+}
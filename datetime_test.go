@@ -0,0 +1,59 @@
+package tomlbuilder_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleAddDateTime() {
+	builder := tomlbuilder.New()
+	builder.AddDateTime("odt1", time.Date(1979, time.May, 27, 7, 32, 0, 0, time.UTC))
+	builder.AddDateTime("odt2", time.Date(1979, time.May, 27, 0, 32, 0, 999999000, time.FixedZone("", -7*60*60)))
+	fmt.Println(builder.String())
+	// Output:
+	// odt1 = 1979-05-27T07:32:00Z
+	// odt2 = 1979-05-27T00:32:00.999999-07:00
+}
+
+func ExampleAddLocalDateTime() {
+	builder := tomlbuilder.New()
+	builder.AddLocalDateTime("ldt", tomlbuilder.NewLocalDateTime(
+		tomlbuilder.NewLocalDate(1979, time.May, 27),
+		tomlbuilder.NewLocalTime(7, 32, 0, 0),
+	))
+	fmt.Println(builder.String())
+	// Output:
+	// ldt = 1979-05-27T07:32:00
+}
+
+func ExampleAddLocalDate() {
+	builder := tomlbuilder.New()
+	builder.AddLocalDate("ld", tomlbuilder.NewLocalDate(1979, time.May, 27))
+	fmt.Println(builder.String())
+	// Output:
+	// ld = 1979-05-27
+}
+
+func ExampleAddLocalTime() {
+	builder := tomlbuilder.New()
+	builder.AddLocalTime("lt", tomlbuilder.NewLocalTime(0, 32, 0, 999999000))
+	fmt.Println(builder.String())
+	// Output:
+	// lt = 00:32:00.999999
+}
+
+func ExampleAddLocalDateArray() {
+	builder := tomlbuilder.New()
+	builder.AddLocalDateArray("dates",
+		tomlbuilder.NewLocalDate(1979, time.May, 27),
+		tomlbuilder.NewLocalDate(2006, time.January, 2),
+	)
+	fmt.Println(builder.String())
+	// Output:
+	// dates = [
+	//   1979-05-27,
+	//   2006-01-02,
+	// ]
+}
@@ -0,0 +1,38 @@
+package tomlbuilder
+
+import "strings"
+
+// AddInlineTable adds an inline table key-value pair to the builder, e.g.
+// `key = { a = 1, b = "x" }`. write is called with a builder that collects
+// its Add* calls as comma-separated key-value pairs rather than writing full
+// lines; nested tables, arrays, and comments are not supported inside an
+// inline table.
+func (w *TomlBuilder) AddInlineTable(key string, write func(*TomlBuilder)) {
+	w.flush()
+	w.checkName(key, "value")
+	if comment := w.takeComment(); comment != "" {
+		w.write("# %v\n", comment)
+	}
+	w.write("%v = %v\n", formatKey(key), w.buildInlineTable(write))
+}
+
+// AddArrayOfInlineTables adds an array of inline tables to the builder, e.g.
+// `key = [{ a = 1 }, { a = 2 }]`. writes is called once per element.
+func (w *TomlBuilder) AddArrayOfInlineTables(key string, writes ...func(*TomlBuilder)) {
+	w.flush()
+	w.checkName(key, "value")
+	if comment := w.takeComment(); comment != "" {
+		w.write("# %v\n", comment)
+	}
+	tables := make([]string, len(writes))
+	for i, write := range writes {
+		tables[i] = w.buildInlineTable(write)
+	}
+	w.write("%v = [%v]\n", formatKey(key), strings.Join(tables, ", "))
+}
+
+func (w *TomlBuilder) buildInlineTable(write func(*TomlBuilder)) string {
+	sub := &TomlBuilder{IndentSize: w.IndentSize, inline: true}
+	write(sub)
+	return "{ " + strings.Join(sub.inlineParts, ", ") + " }"
+}
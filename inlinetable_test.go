@@ -0,0 +1,35 @@
+package tomlbuilder_test
+
+import (
+	"fmt"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleAddInlineTable() {
+	builder := tomlbuilder.New()
+	builder.AddInlineTable("name", func(b *tomlbuilder.TomlBuilder) {
+		b.AddInt("a", 1)
+		b.AddString("b", "x")
+	})
+	fmt.Println(builder.String())
+	// Output:
+	// name = { a = 1, b = "x" }
+}
+
+func ExampleAddArrayOfInlineTables() {
+	builder := tomlbuilder.New()
+	builder.AddArrayOfInlineTables("points",
+		func(b *tomlbuilder.TomlBuilder) {
+			b.AddInt("x", 1)
+			b.AddInt("y", 2)
+		},
+		func(b *tomlbuilder.TomlBuilder) {
+			b.AddInt("x", 3)
+			b.AddInt("y", 4)
+		},
+	)
+	fmt.Println(builder.String())
+	// Output:
+	// points = [{ x = 1, y = 2 }, { x = 3, y = 4 }]
+}
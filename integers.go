@@ -0,0 +1,90 @@
+package tomlbuilder
+
+import "strconv"
+
+// IntStyle controls how AddIntWith renders an integer value.
+type IntStyle struct {
+	// Base is the numeric base to render the integer in: 2, 8, 10, or 16.
+	// Any other value is treated as 10.
+	Base int
+
+	// Underscores, when greater than zero, groups digits into runs of this
+	// many digits (from the right), separated by "_" digit separators.
+	Underscores int
+}
+
+// AddIntWith adds an integer key-value pair to the builder, rendered using
+// the given IntStyle.
+func (w *TomlBuilder) AddIntWith(key string, value int, style IntStyle) {
+	w.addKV(key, formatIntWith(value, style))
+}
+
+// AddHexInt adds an integer key-value pair to the builder, rendered as a
+// hexadecimal literal, e.g. 0xdeadbeef.
+func (w *TomlBuilder) AddHexInt(key string, value int) {
+	w.AddIntWith(key, value, IntStyle{Base: 16})
+}
+
+// AddOctalInt adds an integer key-value pair to the builder, rendered as an
+// octal literal, e.g. 0o755.
+func (w *TomlBuilder) AddOctalInt(key string, value int) {
+	w.AddIntWith(key, value, IntStyle{Base: 8})
+}
+
+// AddBinaryInt adds an integer key-value pair to the builder, rendered as a
+// binary literal, e.g. 0b11010110.
+func (w *TomlBuilder) AddBinaryInt(key string, value int) {
+	w.AddIntWith(key, value, IntStyle{Base: 2})
+}
+
+func formatIntWith(value int, style IntStyle) string {
+	prefix := ""
+	base := style.Base
+	switch base {
+	case 2:
+		prefix = "0b"
+	case 8:
+		prefix = "0o"
+	case 16:
+		prefix = "0x"
+	default:
+		base = 10
+	}
+
+	digits := strconv.FormatInt(int64(value), base)
+	neg := ""
+	if base != 10 && value < 0 {
+		// TOML non-decimal integer literals have no sign; callers should
+		// only use them for values that make sense unsigned.
+		digits = strconv.FormatUint(uint64(int64(value)), base)
+	} else if value < 0 {
+		neg = "-"
+		digits = digits[1:]
+	}
+
+	if style.Underscores > 0 {
+		digits = groupDigits(digits, style.Underscores)
+	}
+	return neg + prefix + digits
+}
+
+// groupDigits inserts "_" separators every n digits, counting from the
+// right.
+func groupDigits(digits string, n int) string {
+	if len(digits) <= n {
+		return digits
+	}
+	var groups []string
+	for len(digits) > n {
+		split := len(digits) - n
+		groups = append([]string{digits[split:]}, groups...)
+		digits = digits[:split]
+	}
+	groups = append([]string{digits}, groups...)
+
+	result := groups[0]
+	for _, g := range groups[1:] {
+		result += "_" + g
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package tomlbuilder_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleAddHexInt() {
+	builder := tomlbuilder.New()
+	builder.AddHexInt("color", 0xdeadbeef)
+	fmt.Println(builder.String())
+	// Output:
+	// color = 0xdeadbeef
+}
+
+func ExampleAddOctalInt() {
+	builder := tomlbuilder.New()
+	builder.AddOctalInt("permissions", 0o755)
+	fmt.Println(builder.String())
+	// Output:
+	// permissions = 0o755
+}
+
+func ExampleAddBinaryInt() {
+	builder := tomlbuilder.New()
+	builder.AddBinaryInt("flags", 0b11010110)
+	fmt.Println(builder.String())
+	// Output:
+	// flags = 0b11010110
+}
+
+func ExampleAddIntWith() {
+	builder := tomlbuilder.New()
+	builder.AddIntWith("color", 0xdeadbeef, tomlbuilder.IntStyle{Base: 16, Underscores: 4})
+	fmt.Println(builder.String())
+	// Output:
+	// color = 0xdead_beef
+}
+
+func ExampleAddFloat_special() {
+	builder := tomlbuilder.New()
+	builder.AddFloat("a", math.Inf(1))
+	builder.AddFloat("b", math.Inf(-1))
+	builder.AddFloat("c", math.NaN())
+	fmt.Println(builder.String())
+	// Output:
+	// a = inf
+	// b = -inf
+	// c = nan
+}
@@ -0,0 +1,24 @@
+package tomlbuilder
+
+import (
+	"regexp"
+	"strings"
+)
+
+// bareKeyPattern matches TOML bare keys, which may contain only ASCII
+// letters, digits, underscores, and dashes.
+var bareKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// formatKey renders key as a TOML key, splitting it on "." into a dotted
+// key and quoting any segment that is not a valid bare key as a basic
+// string. This lets callers write dotted keys directly, e.g.
+// AddString("servers.alpha.ip", "10.0.0.1").
+func formatKey(key string) string {
+	segments := strings.Split(key, ".")
+	for i, seg := range segments {
+		if !bareKeyPattern.MatchString(seg) {
+			segments[i] = quoteBasicString(seg)
+		}
+	}
+	return strings.Join(segments, ".")
+}
@@ -0,0 +1,35 @@
+package tomlbuilder_test
+
+import (
+	"fmt"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleAddString_quotedKey() {
+	builder := tomlbuilder.New()
+	builder.AddString("weird key", "value")
+	fmt.Println(builder.String())
+	// Output:
+	// "weird key" = "value"
+}
+
+func ExampleAddString_dottedKey() {
+	builder := tomlbuilder.New()
+	builder.AddString("servers.alpha.ip", "10.0.0.1")
+	fmt.Println(builder.String())
+	// Output:
+	// servers.alpha.ip = "10.0.0.1"
+}
+
+func ExampleTomlBuilder_strict() {
+	builder := tomlbuilder.New()
+	builder.Strict = true
+	builder.AddString("name", "a")
+	builder.AddInt("name", 1)
+	for _, err := range builder.Errors() {
+		fmt.Println(err)
+	}
+	// Output:
+	// tomlbuilder: value "name" conflicts with previously defined value "name"
+}
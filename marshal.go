@@ -0,0 +1,385 @@
+package tomlbuilder
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Marshaler is implemented by types that can render themselves as a TOML
+// value, as an escape hatch from Marshal's default reflection-based
+// encoding.
+type Marshaler interface {
+	MarshalTOML() (string, error)
+}
+
+// Marshal returns the TOML encoding of v.
+//
+// Marshal traverses v, which must be a struct or a pointer to one, and
+// encodes its exported fields as top-level keys. A field's key defaults to
+// its lowercased name, but can be overridden with a `toml:"name"` struct
+// tag; a `toml:"-"` tag skips the field, and a `,omitempty` option skips the
+// field when it holds its zero value. Embedded structs are flattened into
+// the parent. Maps with string keys are encoded as tables, and slices of
+// structs are encoded as arrays of tables. Values implementing Marshaler or
+// encoding.TextMarshaler are encoded using that method instead of the
+// default rules.
+//
+// Within a struct, scalar fields are always written before table-producing
+// fields (nested structs, maps, and slices of structs), regardless of their
+// declaration order. TOML requires this: any key=value line written after a
+// [table] header belongs to that table, so a scalar field declared after a
+// table-producing one would otherwise be silently absorbed into it.
+func Marshal(v interface{}) ([]byte, error) {
+	b := New()
+	if err := marshalStruct(b, reflect.ValueOf(v), ""); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// Encoder writes a TOML encoding of Go values to an output stream.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes the TOML encoding of v to the stream, following the same
+// rules as Marshal.
+func (e *Encoder) Encode(v interface{}) error {
+	b := NewWriter(e.w)
+	if err := marshalStruct(b, reflect.ValueOf(v), ""); err != nil {
+		return err
+	}
+	b.Flush()
+	return nil
+}
+
+type tomlTag struct {
+	name      string
+	omitempty bool
+	skip      bool
+}
+
+func parseTag(f reflect.StructField) tomlTag {
+	raw, ok := f.Tag.Lookup("toml")
+	if !ok {
+		return tomlTag{name: strings.ToLower(f.Name)}
+	}
+	parts := strings.Split(raw, ",")
+	tag := tomlTag{name: parts[0]}
+	if tag.name == "-" {
+		tag.skip = true
+	}
+	if tag.name == "" {
+		tag.name = strings.ToLower(f.Name)
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			tag.omitempty = true
+		}
+	}
+	return tag
+}
+
+// namedField is a struct field (or map entry) ready to be marshaled, after
+// tag parsing and embedded-struct flattening.
+type namedField struct {
+	key   string
+	value reflect.Value
+}
+
+// collectFields returns rv's fields in declaration order, with anonymous
+// struct fields flattened in place.
+func collectFields(rv reflect.Value) ([]namedField, error) {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tomlbuilder: Marshal requires a struct, got %s", rv.Kind())
+	}
+
+	rt := rv.Type()
+	var fields []namedField
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue // unexported
+		}
+		fv := rv.Field(i)
+
+		if field.Anonymous && fv.Kind() == reflect.Struct {
+			nested, err := collectFields(fv)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+
+		tag := parseTag(field)
+		if tag.skip {
+			continue
+		}
+		if tag.omitempty && fv.IsZero() {
+			continue
+		}
+		fields = append(fields, namedField{key: tag.name, value: fv})
+	}
+	return fields, nil
+}
+
+// marshalStruct writes rv's fields to b. prefix is the dotted table path rv
+// itself was reached at (e.g. "servers.alpha"), used to give any nested
+// table-producing field its full path; it is "" for the document root.
+//
+// Fields are written in two passes, scalars before tables, so that a scalar
+// field declared after a table-producing field in the struct still lands at
+// the right table level instead of being absorbed into the preceding table.
+func marshalStruct(b *TomlBuilder, rv reflect.Value, prefix string) error {
+	fields, err := collectFields(rv)
+	if err != nil {
+		return err
+	}
+
+	var tableFields []namedField
+	for _, f := range fields {
+		if isTableField(f.value) {
+			tableFields = append(tableFields, f)
+			continue
+		}
+		if err := marshalField(b, f.key, f.value, prefix); err != nil {
+			return fmt.Errorf("tomlbuilder: field %s: %w", f.key, err)
+		}
+	}
+	for _, f := range tableFields {
+		if err := marshalField(b, f.key, f.value, prefix); err != nil {
+			return fmt.Errorf("tomlbuilder: field %s: %w", f.key, err)
+		}
+	}
+	return nil
+}
+
+// isTableField reports whether fv marshals to a table-producing construct
+// (a [table] or [[array of tables]] header) rather than a key=value line.
+func isTableField(fv reflect.Value) bool {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return false
+		}
+		fv = fv.Elem()
+	}
+	if !fv.CanInterface() {
+		return false
+	}
+	if isScalarOverride(fv) {
+		return false
+	}
+	switch fv.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return isStructSlice(fv)
+	}
+	return false
+}
+
+// isScalarOverride reports whether fv is rendered as a single scalar value
+// regardless of its underlying kind: a Marshaler, a TextMarshaler, or one of
+// the date/time wrapper types.
+func isScalarOverride(fv reflect.Value) bool {
+	if _, ok := fv.Interface().(Marshaler); ok {
+		return true
+	}
+	if _, ok := fv.Interface().(encoding.TextMarshaler); ok {
+		return true
+	}
+	switch fv.Interface().(type) {
+	case time.Time, LocalDateTime, LocalDate, LocalTime:
+		return true
+	}
+	return false
+}
+
+// isStructSlice reports whether sv is a non-empty slice or array of structs
+// (or pointers to structs) that should be encoded as an array of tables,
+// rather than a plain TOML array. It consults sv's static element type
+// rather than its first value, so a nil element doesn't hide the slice's
+// true shape.
+func isStructSlice(sv reflect.Value) bool {
+	if sv.Len() == 0 {
+		return false
+	}
+	elemType := sv.Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	return elemType.Kind() == reflect.Struct
+}
+
+func marshalField(b *TomlBuilder, key string, fv reflect.Value, prefix string) error {
+	if fv.CanInterface() {
+		if m, ok := fv.Interface().(Marshaler); ok {
+			s, err := m.MarshalTOML()
+			if err != nil {
+				return err
+			}
+			b.addKV(key, s)
+			return nil
+		}
+		if m, ok := fv.Interface().(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			if err != nil {
+				return err
+			}
+			b.AddString(key, string(text))
+			return nil
+		}
+
+		switch v := fv.Interface().(type) {
+		case time.Time:
+			b.AddDateTime(key, v)
+			return nil
+		case LocalDateTime:
+			b.AddLocalDateTime(key, v)
+			return nil
+		case LocalDate:
+			b.AddLocalDate(key, v)
+			return nil
+		case LocalTime:
+			b.AddLocalTime(key, v)
+			return nil
+		}
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		b.AddString(key, fv.String())
+	case reflect.Bool:
+		b.AddBool(key, fv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b.AddInt(key, int(fv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		b.AddInt(key, int(fv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		b.AddFloat(key, fv.Float())
+	case reflect.Ptr:
+		if fv.IsNil() {
+			return nil
+		}
+		return marshalField(b, key, fv.Elem(), prefix)
+	case reflect.Struct:
+		fullKey := joinPath(prefix, key)
+		var tableErr error
+		b.AddTable(fullKey, func(sub *TomlBuilder) {
+			tableErr = marshalStruct(sub, fv, fullKey)
+		})
+		return tableErr
+	case reflect.Map:
+		return marshalMap(b, key, fv, prefix)
+	case reflect.Slice, reflect.Array:
+		return marshalSlice(b, key, fv, prefix)
+	default:
+		return fmt.Errorf("unsupported kind %s", fv.Kind())
+	}
+	return nil
+}
+
+// joinPath appends key to the dotted table path prefix.
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+func marshalMap(b *TomlBuilder, key string, mv reflect.Value, prefix string) error {
+	if mv.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported map key type %s", mv.Type().Key())
+	}
+	keys := mv.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.String()
+	}
+	sort.Strings(names)
+
+	fullKey := joinPath(prefix, key)
+	var tableErr error
+	b.AddTable(fullKey, func(sub *TomlBuilder) {
+		for _, name := range names {
+			if err := marshalField(sub, name, mv.MapIndex(reflect.ValueOf(name)), fullKey); err != nil {
+				tableErr = err
+				return
+			}
+		}
+	})
+	return tableErr
+}
+
+func marshalSlice(b *TomlBuilder, key string, sv reflect.Value, prefix string) error {
+	n := sv.Len()
+	if n == 0 {
+		b.AddStringArray(key)
+		return nil
+	}
+
+	if isStructSlice(sv) {
+		fullKey := joinPath(prefix, key)
+		for i := 0; i < n; i++ {
+			elem := sv.Index(i)
+			var elemErr error
+			b.AddArrayOfTables(fullKey, func(sub *TomlBuilder) {
+				elemErr = marshalStruct(sub, elem, fullKey)
+			})
+			if elemErr != nil {
+				return elemErr
+			}
+		}
+		return nil
+	}
+
+	elemKind := sv.Index(0).Kind()
+	switch elemKind {
+	case reflect.String:
+		vals := make([]string, n)
+		for i := 0; i < n; i++ {
+			vals[i] = sv.Index(i).String()
+		}
+		b.AddStringArray(key, vals...)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		vals := make([]int, n)
+		for i := 0; i < n; i++ {
+			vals[i] = int(sv.Index(i).Int())
+		}
+		b.AddIntArray(key, vals...)
+	case reflect.Float32, reflect.Float64:
+		vals := make([]float64, n)
+		for i := 0; i < n; i++ {
+			vals[i] = sv.Index(i).Float()
+		}
+		b.AddFloatArray(key, vals...)
+	case reflect.Bool:
+		vals := make([]bool, n)
+		for i := 0; i < n; i++ {
+			vals[i] = sv.Index(i).Bool()
+		}
+		b.AddBoolArray(key, vals...)
+	default:
+		return fmt.Errorf("unsupported slice element kind %s", elemKind)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package tomlbuilder_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+type serverConfig struct {
+	Name    string `toml:"name"`
+	Port    int    `toml:"port"`
+	Enabled bool   `toml:"enabled,omitempty"`
+}
+
+func ExampleMarshal() {
+	data, err := tomlbuilder.Marshal(serverConfig{Name: "alpha", Port: 8080})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(data))
+	// Output:
+	// name = "alpha"
+	// port = 8080
+}
+
+func ExampleNewEncoder() {
+	var buf bytes.Buffer
+	err := tomlbuilder.NewEncoder(&buf).Encode(serverConfig{Name: "alpha", Port: 8080, Enabled: true})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(buf.String())
+	// Output:
+	// name = "alpha"
+	// port = 8080
+	// enabled = true
+}
+
+type innerConfig struct {
+	Deep deepConfig `toml:"deep"`
+}
+
+type deepConfig struct {
+	Host string `toml:"host"`
+}
+
+type appConfig struct {
+	Server serverConfig `toml:"server"`
+	Nested innerConfig  `toml:"nested"`
+	Name   string       `toml:"name"`
+}
+
+// ExampleMarshal_ordering verifies that a scalar field declared after a
+// table-producing field is still written as a root key, not absorbed into
+// the preceding table, and that doubly-nested structs emit a fully dotted
+// table header.
+func ExampleMarshal_ordering() {
+	data, err := tomlbuilder.Marshal(appConfig{
+		Server: serverConfig{Name: "alpha", Port: 8080},
+		Nested: innerConfig{Deep: deepConfig{Host: "localhost"}},
+		Name:   "app",
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(string(data))
+	// Output:
+	// name = "app"
+	// [server]
+	// name = "alpha"
+	// port = 8080
+	// [nested]
+	// [nested.deep]
+	// host = "localhost"
+}
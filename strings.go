@@ -0,0 +1,133 @@
+package tomlbuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StringStyle selects how AddStringWith renders a string value.
+type StringStyle int
+
+const (
+	// StringStyleBasic renders the value as a basic string, e.g. "hello".
+	StringStyleBasic StringStyle = iota
+	// StringStyleLiteral renders the value as a literal string, e.g. 'hello'.
+	// No escaping is performed, so the value must not contain a single quote.
+	StringStyleLiteral
+	// StringStyleMultiline renders the value as a multi-line basic string,
+	// e.g. """hello""".
+	StringStyleMultiline
+	// StringStyleMultilineLiteral renders the value as a multi-line literal
+	// string, e.g. '''hello'''. No escaping is performed, so the value must
+	// not contain three consecutive single quotes.
+	StringStyleMultilineLiteral
+)
+
+// AddString adds a string key-value pair to the builder, escaping control
+// characters, double quotes, and backslashes as required by a TOML basic
+// string.
+func (w *TomlBuilder) AddString(key string, value string) {
+	w.addKV(key, quoteBasicString(value))
+}
+
+// AddStringWith adds a string key-value pair to the builder, rendered using
+// the given StringStyle.
+func (w *TomlBuilder) AddStringWith(key string, value string, style StringStyle) {
+	w.addKV(key, formatStringWith(value, style))
+}
+
+// AddLiteralString adds a string key-value pair to the builder, rendered as
+// a single-quoted TOML literal string.
+func (w *TomlBuilder) AddLiteralString(key string, value string) {
+	w.AddStringWith(key, value, StringStyleLiteral)
+}
+
+// AddMultilineString adds a string key-value pair to the builder, rendered
+// as a triple-quoted TOML multi-line basic string.
+func (w *TomlBuilder) AddMultilineString(key string, value string) {
+	w.AddStringWith(key, value, StringStyleMultiline)
+}
+
+// AddMultilineLiteralString adds a string key-value pair to the builder,
+// rendered as a triple-single-quoted TOML multi-line literal string.
+func (w *TomlBuilder) AddMultilineLiteralString(key string, value string) {
+	w.AddStringWith(key, value, StringStyleMultilineLiteral)
+}
+
+func formatStringWith(value string, style StringStyle) string {
+	switch style {
+	case StringStyleLiteral:
+		return "'" + value + "'"
+	case StringStyleMultiline:
+		return `"""` + escapeMultilineString(value) + `"""`
+	case StringStyleMultilineLiteral:
+		return "'''" + value + "'''"
+	default:
+		return quoteBasicString(value)
+	}
+}
+
+func quoteBasicString(value string) string {
+	return `"` + escapeString(value) + `"`
+}
+
+// escapeString escapes the characters that are not permitted unescaped in a
+// TOML basic string: backslash, double quote, and control characters.
+func escapeString(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// escapeMultilineString is like escapeString, but leaves newlines and tabs
+// unescaped since both are permitted to appear literally within a TOML
+// multi-line basic string.
+func escapeMultilineString(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch r {
+		case '\n', '\t':
+			b.WriteRune(r)
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
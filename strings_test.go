@@ -0,0 +1,52 @@
+package tomlbuilder_test
+
+import (
+	"fmt"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleAddString_escaping() {
+	builder := tomlbuilder.New()
+	builder.AddString("quote", `she said "hi"`)
+	builder.AddString("backslash", `C:\temp`)
+	builder.AddString("newline", "line1\nline2")
+	fmt.Println(builder.String())
+	// Output:
+	// quote = "she said \"hi\""
+	// backslash = "C:\\temp"
+	// newline = "line1\nline2"
+}
+
+func ExampleAddLiteralString() {
+	builder := tomlbuilder.New()
+	builder.AddLiteralString("path", `C:\Users\nodejs\templates`)
+	fmt.Println(builder.String())
+	// Output:
+	// path = 'C:\Users\nodejs\templates'
+}
+
+func ExampleAddMultilineString() {
+	builder := tomlbuilder.New()
+	builder.AddMultilineString("greeting", "Hello\nWorld")
+	fmt.Println(builder.String())
+	// Output:
+	// greeting = """Hello
+	// World"""
+}
+
+func ExampleAddMultilineLiteralString() {
+	builder := tomlbuilder.New()
+	builder.AddMultilineLiteralString("regex", `\d+\s*`)
+	fmt.Println(builder.String())
+	// Output:
+	// regex = '''\d+\s*'''
+}
+
+func ExampleAddStringWith() {
+	builder := tomlbuilder.New()
+	builder.AddStringWith("key", "value", tomlbuilder.StringStyleLiteral)
+	fmt.Println(builder.String())
+	// Output:
+	// key = 'value'
+}
@@ -4,7 +4,10 @@ package tomlbuilder
 import (
 	"bytes"
 	"fmt"
+	"io"
+	"math"
 	"strconv"
+	"strings"
 )
 
 // TomlBuilder is used to create TOML files.
@@ -14,10 +17,10 @@ import (
 // * Integer
 // * Float
 // * Boolean
-// [TODO] Offset Date-time
-// [TODO] Local Date-time
-// [TODO] Local Date
-// [TODO] Local Time
+// * Offset Date-time
+// * Local Date-time
+// * Local Date
+// * Local Time
 // * Array
 // * Table
 // * Inline Table
@@ -48,54 +51,146 @@ import (
 type TomlBuilder struct {
 	IndentSize int
 
+	// Strict, when true, causes the builder to record an error (retrievable
+	// via Errors) for each duplicate key and each table/key name conflict it
+	// detects, such as the [fruit.variety] vs [[fruit.variety]] conflict
+	// described above. Strict mode only catches conflicts between values
+	// added through the same TomlBuilder; it is not a full TOML validator.
+	Strict bool
+
+	// TableSpacing is the number of blank lines written before each
+	// top-level table or array of tables, after the first. It has no effect
+	// on nested tables. The default, 0, matches the builder's historical
+	// behavior of no spacing.
+	TableSpacing int
+
 	indentation string
-	buf         *bytes.Buffer
+	buf         *bytes.Buffer // nil when streaming directly to out
+	out         io.Writer     // nil unless created by NewWriter
+	isRoot      bool          // true only for builders returned by New/NewWriter
+
+	// inline is true when this builder is collecting key-value pairs for an
+	// inline table rather than writing full lines to buf. See AddInlineTable.
+	inline      bool
+	inlineParts []string
+
+	// used records the names declared directly at this builder's level, for
+	// Strict duplicate/conflict detection. errs is shared with every
+	// TomlBuilder produced from the same root, so errors recorded by nested
+	// tables surface from the root's Errors call.
+	used map[string]string
+	errs *[]error
+
+	// align, when set via SetKeyValueAlignment, buffers key-value lines
+	// written directly at this builder's level into pending so their "="
+	// signs can be column-aligned once the run of lines is known to be
+	// complete. pendingComment holds a comment queued by Comment, to be
+	// emitted above the next line this builder writes.
+	align          bool
+	pending        []pendingKV
+	pendingComment string
+	wroteTable     bool
+}
+
+// pendingKV is a key-value line buffered for alignment; see
+// TomlBuilder.align.
+type pendingKV struct {
+	comment string
+	key     string
+	value   string
 }
 
-// New creates a new TomlBuilder.
+// New creates a new TomlBuilder that buffers its output in memory; call
+// String to retrieve it.
 func New() *TomlBuilder {
 	return &TomlBuilder{
 		IndentSize: 2,
 
 		indentation: "",
 		buf:         new(bytes.Buffer),
+		isRoot:      true,
+		used:        map[string]string{},
+		errs:        new([]error),
 	}
 }
 
+// NewWriter creates a TomlBuilder that streams its output to out as it is
+// produced, rather than buffering it in memory. String always returns "" for
+// a TomlBuilder created this way.
+//
+// If SetKeyValueAlignment is enabled on a TomlBuilder created with NewWriter,
+// call Flush after the last Add* call: aligned lines are buffered until the
+// width of the run is known, and a streaming builder has no final String
+// call to trigger that flush automatically.
+func NewWriter(out io.Writer) *TomlBuilder {
+	return &TomlBuilder{
+		IndentSize: 2,
+		out:        out,
+		isRoot:     true,
+		used:       map[string]string{},
+		errs:       new([]error),
+	}
+}
+
+// Errors returns the errors recorded in Strict mode, in the order they were
+// detected. It returns nil if Strict is false or no conflicts were found.
+func (w *TomlBuilder) Errors() []error {
+	if w.errs == nil {
+		return nil
+	}
+	return *w.errs
+}
+
 // AddNewLine adds a new line to the builder.
 func (w *TomlBuilder) AddNewLine() {
+	w.flush()
 	w.write("\n")
 }
 
-// AddComment adds a comment to the builder.
+// AddComment adds a free-floating comment to the builder. To attach a
+// comment to a specific key or table instead, use Comment or AddTableC.
 func (w *TomlBuilder) AddComment(msg string) {
+	w.flush()
 	w.write("# %v", msg)
 }
 
-// AddString adds a string key-value pair to the builder.
-func (w *TomlBuilder) AddString(key string, value string) {
-	w.write("%v = \"%v\"\n", key, value)
+// Comment queues msg to be written as a "# msg" line immediately above the
+// next key, table, or array of tables added through this builder, then
+// clears itself. It returns the builder so calls can be chained, e.g.
+// builder.Comment("doc line").AddString("key", "value").
+func (w *TomlBuilder) Comment(msg string) *TomlBuilder {
+	w.pendingComment = msg
+	return w
+}
+
+// SetKeyValueAlignment enables or disables column-aligning the "=" signs of
+// consecutive key-value lines written directly at this builder's level. The
+// setting applies to lines written after it is called, and does not affect
+// nested tables.
+func (w *TomlBuilder) SetKeyValueAlignment(enabled bool) {
+	w.flush()
+	w.align = enabled
 }
 
 // AddInt adds an integer key-value pair to the builder.
 func (w *TomlBuilder) AddInt(key string, value int) {
-	w.write("%v = %v\n", key, value)
+	w.addKV(key, fmt.Sprintf("%v", value))
 }
 
 // AddFloat adds a float key-value pair to the builder.
 func (w *TomlBuilder) AddFloat(key string, value float64) {
-	w.write("%v = %v\n", key, formatFloat(value))
+	w.addKV(key, formatFloat(value))
 }
 
 func (w *TomlBuilder) AddBool(key string, value bool) {
-	w.write("%v = %v\n", key, strconv.FormatBool(value))
+	w.addKV(key, strconv.FormatBool(value))
 }
 
 // AddStringArray adds an array of strings to the builder.
 func (w *TomlBuilder) AddStringArray(key string, array ...string) {
 	vals := make([]string, len(array))
 	for i, val := range array {
-		vals[i] = fmt.Sprintf("\"%v\"", string(val))
+		vals[i] = quoteBasicString(val)
 	}
 	w.addArray(key, vals)
 }
@@ -127,7 +222,12 @@ func (w *TomlBuilder) AddBoolArray(key string, array ...bool) {
 }
 
 func (w *TomlBuilder) addArray(key string, array []string) {
-	w.write("%v = [\n", key)
+	w.flush()
+	w.checkName(key, "value")
+	if comment := w.takeComment(); comment != "" {
+		w.write("# %v\n", comment)
+	}
+	w.write("%v = [\n", formatKey(key))
 	w.indent()
 	for _, val := range array {
 		w.write("%v,\n", val)
@@ -140,34 +240,154 @@ func (w *TomlBuilder) addArray(key string, array []string) {
 
 // AddTable adds a table to the builder.
 func (w *TomlBuilder) AddTable(name string, write func(*TomlBuilder)) {
-	w.write("[%v]\n", name)
-	write(&TomlBuilder{
-		IndentSize: w.IndentSize,
+	w.beforeTable()
+	w.checkName(name, "table")
+	w.write("[%v]\n", formatKey(name))
+	sub := w.subBuilder()
+	write(sub)
+	sub.flush()
+}
 
-		indentation: w.indentation,
-		buf:         w.buf,
-	})
+// AddTableC is like AddTable, but writes comment as a "# comment" line
+// immediately above the table header.
+func (w *TomlBuilder) AddTableC(name, comment string, write func(*TomlBuilder)) {
+	w.Comment(comment).AddTable(name, write)
 }
 
 // AddArrayOfTables adds an array of tables to the builder.  name is the name of
 // the array.  write is the callback used to build the contents of the array.
 func (w *TomlBuilder) AddArrayOfTables(name string, write func(*TomlBuilder)) {
-	w.write("[[%v]]\n", name)
-	write(&TomlBuilder{
+	w.beforeTable()
+	w.checkName(name, "array table")
+	w.write("[[%v]]\n", formatKey(name))
+	sub := w.subBuilder()
+	write(sub)
+	sub.flush()
+}
+
+// beforeTable flushes any pending aligned key-value lines and comment, and
+// inserts TableSpacing blank lines before a top-level table header if this
+// is not the first top-level table written.
+func (w *TomlBuilder) beforeTable() {
+	w.flush()
+	if w.isRoot && w.TableSpacing > 0 {
+		if w.wroteTable {
+			for i := 0; i < w.TableSpacing; i++ {
+				w.write("\n")
+			}
+		}
+		w.wroteTable = true
+	}
+	if comment := w.takeComment(); comment != "" {
+		w.write("# %v\n", comment)
+	}
+}
+
+// subBuilder creates a TomlBuilder that shares this builder's output but
+// writes at its current indentation level, for use by AddTable and
+// AddArrayOfTables callbacks.
+func (w *TomlBuilder) subBuilder() *TomlBuilder {
+	return &TomlBuilder{
 		IndentSize: w.IndentSize,
+		Strict:     w.Strict,
 
 		indentation: w.indentation,
 		buf:         w.buf,
-	})
+		out:         w.out,
+		used:        map[string]string{},
+		errs:        w.errs,
+	}
+}
+
+// checkName records an error in Strict mode if name has already been
+// declared at this builder's level, whether as a value, table, or array of
+// tables.
+func (w *TomlBuilder) checkName(name, kind string) {
+	if !w.Strict {
+		return
+	}
+	if prev, ok := w.used[name]; ok {
+		*w.errs = append(*w.errs, fmt.Errorf("tomlbuilder: %v %q conflicts with previously defined %v %q", kind, name, prev, name))
+		return
+	}
+	w.used[name] = kind
 }
 
 // String converts the builder's buffer into a string of TOML file contents.
+// It returns "" for a TomlBuilder created with NewWriter, which streams its
+// output instead of buffering it.
 func (w *TomlBuilder) String() string {
+	w.flush()
+	if w.buf == nil {
+		return ""
+	}
 	return w.buf.String()
 }
 
 func (w *TomlBuilder) write(format string, args ...interface{}) {
-	w.buf.Write([]byte(w.indentation + fmt.Sprintf(format, args...)))
+	data := []byte(w.indentation + fmt.Sprintf(format, args...))
+	if w.out != nil {
+		w.out.Write(data)
+		return
+	}
+	w.buf.Write(data)
+}
+
+// addKV writes a key-value pair. If the builder is collecting an inline
+// table, the pair is buffered into inlineParts instead of being written
+// directly; see AddInlineTable.
+func (w *TomlBuilder) addKV(key, value string) {
+	if w.inline {
+		w.inlineParts = append(w.inlineParts, fmt.Sprintf("%v = %v", formatKey(key), value))
+		return
+	}
+	w.checkName(key, "value")
+	comment := w.takeComment()
+	if w.align {
+		w.pending = append(w.pending, pendingKV{comment: comment, key: formatKey(key), value: value})
+		return
+	}
+	if comment != "" {
+		w.write("# %v\n", comment)
+	}
+	w.write("%v = %v\n", formatKey(key), value)
+}
+
+// takeComment returns and clears the comment queued by Comment, if any.
+func (w *TomlBuilder) takeComment() string {
+	c := w.pendingComment
+	w.pendingComment = ""
+	return c
+}
+
+// Flush writes out any key-value lines currently buffered for alignment by
+// SetKeyValueAlignment. String calls this automatically, so Flush is only
+// needed on a TomlBuilder created with NewWriter, which streams output
+// directly to an io.Writer and has no final String call of its own to
+// trigger it.
+func (w *TomlBuilder) Flush() {
+	w.flush()
+}
+
+// flush writes out any key-value lines buffered for alignment by
+// SetKeyValueAlignment, column-aligning their "=" signs.
+func (w *TomlBuilder) flush() {
+	if len(w.pending) == 0 {
+		return
+	}
+	width := 0
+	for _, p := range w.pending {
+		if len(p.key) > width {
+			width = len(p.key)
+		}
+	}
+	for _, p := range w.pending {
+		if p.comment != "" {
+			w.write("# %v\n", p.comment)
+		}
+		w.write("%v%v = %v\n", p.key, strings.Repeat(" ", width-len(p.key)), p.value)
+	}
+	w.pending = nil
 }
 
 func (w *TomlBuilder) indent() {
@@ -187,8 +407,16 @@ func (w *TomlBuilder) unindent() {
 }
 
 func formatFloat(val float64) string {
-	if val == float64(int64(val)) {
+	switch {
+	case math.IsNaN(val):
+		return "nan"
+	case math.IsInf(val, 1):
+		return "inf"
+	case math.IsInf(val, -1):
+		return "-inf"
+	case val == float64(int64(val)):
 		return fmt.Sprintf("%v.0", val)
+	default:
+		return fmt.Sprintf("%v", strconv.FormatFloat(val, 'f', -1, 64))
 	}
-	return fmt.Sprintf("%v", strconv.FormatFloat(val, 'f', -1, 64))
 }
@@ -0,0 +1,32 @@
+package tomlbuilder_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/kharland/tomlbuilder"
+)
+
+func ExampleNewWriter() {
+	var buf bytes.Buffer
+	builder := tomlbuilder.NewWriter(&buf)
+	builder.AddString("name", "alpha")
+	builder.AddInt("port", 8080)
+	fmt.Print(buf.String())
+	// Output:
+	// name = "alpha"
+	// port = 8080
+}
+
+func ExampleTomlBuilder_Flush() {
+	var buf bytes.Buffer
+	builder := tomlbuilder.NewWriter(&buf)
+	builder.SetKeyValueAlignment(true)
+	builder.AddString("name", "alpha")
+	builder.AddInt("port", 8080)
+	builder.Flush()
+	fmt.Print(buf.String())
+	// Output:
+	// name = "alpha"
+	// port = 8080
+}